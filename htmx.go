@@ -1,6 +1,15 @@
 package htmx
 
-import "strconv"
+import (
+  "encoding/json"
+  "fmt"
+  "html"
+  "io"
+  "net/url"
+  "strconv"
+  "strings"
+  "time"
+)
 
 type Swap string
 
@@ -16,6 +25,106 @@ const (
   SwapNone        Swap = "none"
 )
 
+// SwapSpec builds a composite HX-Reswap value from htmx's documented swap
+// modifiers (swap/settle delay, scroll, show, focus-scroll, transition) on
+// top of a base Swap style. Construct one with NewSwap and chain the
+// modifiers you need.
+type SwapSpec struct {
+  style          Swap
+  swapDelay      time.Duration
+  settleDelay    time.Duration
+  scrollPosition string
+  showSelector   string
+  showPosition   string
+  focusScroll    *bool
+  transition     *bool
+}
+
+// NewSwap starts a SwapSpec with the given base swap style.
+func NewSwap(style Swap) SwapSpec {
+  return SwapSpec{style: style}
+}
+
+// SwapDelay sets the swap:<ms>ms modifier, delaying the swap itself.
+func (s SwapSpec) SwapDelay(delay time.Duration) SwapSpec {
+  s.swapDelay = delay
+  return s
+}
+
+// SettleDelay sets the settle:<ms>ms modifier, delaying the settle step.
+func (s SwapSpec) SettleDelay(delay time.Duration) SwapSpec {
+  s.settleDelay = delay
+  return s
+}
+
+// ScrollTo sets the scroll:<position> modifier (e.g. "top" or "bottom").
+func (s SwapSpec) ScrollTo(position string) SwapSpec {
+  s.scrollPosition = position
+  return s
+}
+
+// ShowElement sets the show:<selector>:<position> modifier.
+func (s SwapSpec) ShowElement(selector, position string) SwapSpec {
+  s.showSelector = selector
+  s.showPosition = position
+  return s
+}
+
+// FocusScroll sets the focus-scroll:<bool> modifier.
+func (s SwapSpec) FocusScroll(focusScroll bool) SwapSpec {
+  s.focusScroll = &focusScroll
+  return s
+}
+
+// Transition sets the transition:<bool> modifier.
+func (s SwapSpec) Transition(transition bool) SwapSpec {
+  s.transition = &transition
+  return s
+}
+
+// String renders the spec into the modifier string htmx expects for
+// HX-Reswap, e.g. "outerHTML swap:500ms settle:200ms scroll:top".
+func (s SwapSpec) String() string {
+  parts := []string{string(s.style)}
+  if s.swapDelay > 0 {
+    parts = append(parts, "swap:"+formatMillis(s.swapDelay))
+  }
+  if s.settleDelay > 0 {
+    parts = append(parts, "settle:"+formatMillis(s.settleDelay))
+  }
+  if s.scrollPosition != "" {
+    parts = append(parts, "scroll:"+s.scrollPosition)
+  }
+  if s.showSelector != "" {
+    parts = append(parts, "show:"+s.showSelector+":"+s.showPosition)
+  }
+  if s.focusScroll != nil {
+    parts = append(parts, "focus-scroll:"+strconv.FormatBool(*s.focusScroll))
+  }
+  if s.transition != nil {
+    parts = append(parts, "transition:"+strconv.FormatBool(*s.transition))
+  }
+  return strings.Join(parts, " ")
+}
+
+// formatMillis renders delay in the "<n>ms" form htmx's parseInterval
+// understands (it only parses bare numbers plus ms/s/m suffixes), rounding
+// down to the nearest millisecond rather than delegating to
+// time.Duration.String, which emits µs/ns suffixes htmx can't parse.
+func formatMillis(delay time.Duration) string {
+  return strconv.FormatInt(delay.Milliseconds(), 10) + "ms"
+}
+
+// OOB is a single out-of-band swap fragment queued via Htmx.AddOOB:
+// Selector identifies the existing element to update (used as its id),
+// HTML is its replacement content, and Swap controls how it is swapped in
+// ("" defaults to htmx's own out-of-band default).
+type OOB struct {
+  Selector string
+  HTML     string
+  Swap     Swap
+}
+
 type HtmxRequest struct {
   Request               bool
   Boosted               bool
@@ -41,6 +150,39 @@ type HtmxResponse struct {
   TriggerAfterSwap   string
 }
 
+// LocationSpec mirrors htmx's HX-Location JSON contract, letting a response
+// trigger a client-side navigation to Path while controlling how it is
+// loaded (e.g. into a specific Target with a given Swap) instead of a full
+// page load.
+type LocationSpec struct {
+  Path    string
+  Source  string
+  Event   string
+  Handler string
+  Target  string
+  Swap    string
+  Select  string
+  Values  map[string]string
+  Headers map[string]string
+}
+
+func (s LocationSpec) hasExtras() bool {
+  return s.Source != "" || s.Event != "" || s.Handler != "" || s.Target != "" ||
+    s.Swap != "" || s.Select != "" || len(s.Values) > 0 || len(s.Headers) > 0
+}
+
+type locationSpecJSON struct {
+  Path    string            `json:"path"`
+  Source  string            `json:"source,omitempty"`
+  Event   string            `json:"event,omitempty"`
+  Handler string            `json:"handler,omitempty"`
+  Target  string            `json:"target,omitempty"`
+  Swap    string            `json:"swap,omitempty"`
+  Select  string            `json:"select,omitempty"`
+  Values  map[string]string `json:"values,omitempty"`
+  Headers map[string]string `json:"headers,omitempty"`
+}
+
 type RequestHeader interface {
   Get(key string) string
 }
@@ -56,6 +198,24 @@ type ResponseHeader interface {
 type Htmx struct {
   Request  HtmxRequest
   Response HtmxResponse
+
+  triggerEvents            []TriggerEvent
+  triggerAfterSettleEvents []TriggerEvent
+  triggerAfterSwapEvents   []TriggerEvent
+
+  locationSpec *LocationSpec
+
+  oob []OOB
+
+  header any
+}
+
+// TriggerEvent is a single named client-side event queued for HX-Trigger,
+// HX-Trigger-After-Settle, or HX-Trigger-After-Swap. Detail is marshaled as
+// the event's payload and may be nil for a plain, detail-less event.
+type TriggerEvent struct {
+  Name   string
+  Detail any
 }
 
 func New(header RequestHeader) *Htmx {
@@ -79,6 +239,7 @@ func NewUniversal(header any) *Htmx {
       Trigger:               getHeader(header, "HX-Trigger"),
     },
     Response: HtmxResponse{},
+    header:   header,
   }
 }
 
@@ -102,6 +263,29 @@ func (h *Htmx) IsHistoryRestoreRequest() bool {
   return h.Request.HistoryRestoreRequest
 }
 
+// HistoryRestore is an alias for IsHistoryRestoreRequest.
+func (h *Htmx) HistoryRestore() bool {
+  return h.Request.HistoryRestoreRequest
+}
+
+// CurrentURL parses HX-Current-URL into a *url.URL so handlers can inspect
+// its query or path without re-parsing the raw header themselves.
+func (h *Htmx) CurrentURL() (*url.URL, error) {
+  return url.Parse(h.Request.CurrentUrl)
+}
+
+// Header reads an arbitrary HX-* (or any other) request header not already
+// modeled by HtmxRequest.
+func (h *Htmx) Header(key string) string {
+  return getHeader(h.header, key)
+}
+
+// Is reports whether HX-Trigger matches triggerID, a common branch in htmx
+// handlers driven by which element triggered the request.
+func (h *Htmx) Is(triggerID string) bool {
+  return h.Request.Trigger == triggerID
+}
+
 func (h *Htmx) GetPrompt() string {
   return h.Request.Prompt
 }
@@ -120,6 +304,16 @@ func (h *Htmx) GetTrigger() string {
 
 func (h *Htmx) Location(location string) *Htmx {
   h.Response.Location = location
+  h.locationSpec = nil
+  return h
+}
+
+// LocationWith sets HX-Location from a full LocationSpec, allowing a
+// client-side navigation that targets a specific element and swap strategy
+// instead of a full page load.
+func (h *Htmx) LocationWith(spec LocationSpec) *Htmx {
+  h.locationSpec = &spec
+  h.Response.Location = spec.Path
   return h
 }
 
@@ -148,6 +342,14 @@ func (h *Htmx) Reswap(swap Swap) *Htmx {
   return h
 }
 
+// ReswapSpec sets HX-Reswap from a SwapSpec, allowing swap/settle timing,
+// scroll, show, focus-scroll, and transition modifiers alongside the base
+// swap style.
+func (h *Htmx) ReswapSpec(spec SwapSpec) *Htmx {
+  h.Response.Reswap = Swap(spec.String())
+  return h
+}
+
 func (h *Htmx) Retarget(selector string) *Htmx {
   h.Response.Retarget = selector
   return h
@@ -158,23 +360,141 @@ func (h *Htmx) Reselect(selector string) *Htmx {
   return h
 }
 
+// Trigger sets a raw HX-Trigger value. It can be combined with TriggerEvent
+// calls as long as none of them carry a detail payload (the raw string is
+// folded in as another event name); mixing it with a detail-carrying event
+// has no safe merge and panics at Apply time.
 func (h *Htmx) Trigger(trigger string) *Htmx {
   h.Response.Trigger = trigger
   return h
 }
 
+// TriggerAfterSettle sets a raw HX-Trigger-After-Settle value. See Trigger
+// for how it combines with TriggerAfterSettleEvent.
 func (h *Htmx) TriggerAfterSettle(trigger string) *Htmx {
   h.Response.TriggerAfterSettle = trigger
   return h
 }
 
+// TriggerAfterSwap sets a raw HX-Trigger-After-Swap value. See Trigger for
+// how it combines with TriggerAfterSwapEvent.
 func (h *Htmx) TriggerAfterSwap(trigger string) *Htmx {
   h.Response.TriggerAfterSwap = trigger
   return h
 }
 
+// TriggerEvent queues a structured client-side event to be emitted via
+// HX-Trigger once the response is applied. detail may be nil for a plain
+// event, or any JSON-marshalable value to send as the event's payload.
+func (h *Htmx) TriggerEvent(name string, detail any) *Htmx {
+  h.triggerEvents = append(h.triggerEvents, TriggerEvent{Name: name, Detail: detail})
+  return h
+}
+
+// TriggerEvents queues multiple structured events for HX-Trigger at once.
+func (h *Htmx) TriggerEvents(events map[string]any) *Htmx {
+  for name, detail := range events {
+    h.triggerEvents = append(h.triggerEvents, TriggerEvent{Name: name, Detail: detail})
+  }
+  return h
+}
+
+// ClearTriggerEvents discards all queued HX-Trigger events.
+func (h *Htmx) ClearTriggerEvents() *Htmx {
+  h.triggerEvents = nil
+  return h
+}
+
+// TriggerAfterSettleEvent queues a structured event for HX-Trigger-After-Settle.
+func (h *Htmx) TriggerAfterSettleEvent(name string, detail any) *Htmx {
+  h.triggerAfterSettleEvents = append(h.triggerAfterSettleEvents, TriggerEvent{Name: name, Detail: detail})
+  return h
+}
+
+// TriggerAfterSettleEvents queues multiple structured events for
+// HX-Trigger-After-Settle at once.
+func (h *Htmx) TriggerAfterSettleEvents(events map[string]any) *Htmx {
+  for name, detail := range events {
+    h.triggerAfterSettleEvents = append(h.triggerAfterSettleEvents, TriggerEvent{Name: name, Detail: detail})
+  }
+  return h
+}
+
+// ClearTriggerAfterSettleEvents discards all queued HX-Trigger-After-Settle events.
+func (h *Htmx) ClearTriggerAfterSettleEvents() *Htmx {
+  h.triggerAfterSettleEvents = nil
+  return h
+}
+
+// TriggerAfterSwapEvent queues a structured event for HX-Trigger-After-Swap.
+func (h *Htmx) TriggerAfterSwapEvent(name string, detail any) *Htmx {
+  h.triggerAfterSwapEvents = append(h.triggerAfterSwapEvents, TriggerEvent{Name: name, Detail: detail})
+  return h
+}
+
+// TriggerAfterSwapEvents queues multiple structured events for
+// HX-Trigger-After-Swap at once.
+func (h *Htmx) TriggerAfterSwapEvents(events map[string]any) *Htmx {
+  for name, detail := range events {
+    h.triggerAfterSwapEvents = append(h.triggerAfterSwapEvents, TriggerEvent{Name: name, Detail: detail})
+  }
+  return h
+}
+
+// ClearTriggerAfterSwapEvents discards all queued HX-Trigger-After-Swap events.
+func (h *Htmx) ClearTriggerAfterSwapEvents() *Htmx {
+  h.triggerAfterSwapEvents = nil
+  return h
+}
+
+// AddOOB queues an out-of-band swap fragment. html replaces the element
+// matched by selector (its id) using swap ("" for htmx's own default)
+// instead of the single main-target swap HX-Swap performs.
+func (h *Htmx) AddOOB(selector string, html string, swap Swap) *Htmx {
+  h.oob = append(h.oob, OOB{Selector: selector, HTML: html, Swap: swap})
+  return h
+}
+
+// Render writes mainHTML followed by an hx-swap-oob wrapper for every
+// fragment queued via AddOOB, letting a single call site compose updates to
+// multiple targets in one response body. Response headers are unaffected;
+// call Apply separately to set them. Selector is HTML-attribute-escaped
+// before being written into the wrapper's id; mainHTML and each fragment's
+// HTML are written as-is, since they are expected to already be markup.
+func (h *Htmx) Render(w io.Writer, mainHTML string) error {
+  if _, err := io.WriteString(w, mainHTML); err != nil {
+    return err
+  }
+  for _, fragment := range h.oob {
+    swapAttr := "true"
+    if fragment.Swap != "" {
+      swapAttr = string(fragment.Swap)
+    }
+    if _, err := fmt.Fprintf(w, `<div hx-swap-oob="%s" id="%s">%s</div>`, html.EscapeString(swapAttr), html.EscapeString(fragment.Selector), fragment.HTML); err != nil {
+      return err
+    }
+  }
+  return nil
+}
+
 func (h *Htmx) Apply(header ResponseHeader) *Htmx {
-  if h.Response.Location != "" {
+  if h.locationSpec != nil && h.locationSpec.hasExtras() {
+    encoded, err := json.Marshal(locationSpecJSON{
+      Path:    h.locationSpec.Path,
+      Source:  h.locationSpec.Source,
+      Event:   h.locationSpec.Event,
+      Handler: h.locationSpec.Handler,
+      Target:  h.locationSpec.Target,
+      Swap:    h.locationSpec.Swap,
+      Select:  h.locationSpec.Select,
+      Values:  h.locationSpec.Values,
+      Headers: h.locationSpec.Headers,
+    })
+    if err != nil {
+      panic("htmx: failed to marshal location spec: " + err.Error())
+    }
+    header.Set("HX-Location", string(encoded))
+  } else if h.Response.Location != "" {
     header.Set("HX-Location", h.Response.Location)
   }
   if h.Response.PushUrl != "" {
@@ -198,18 +518,68 @@ func (h *Htmx) Apply(header ResponseHeader) *Htmx {
   if h.Response.Reselect != "" {
     header.Set("HX-Reselect", h.Response.Reselect)
   }
-  if h.Response.Trigger != "" {
-    header.Set("HX-Trigger", h.Response.Trigger)
+  if trigger := encodeTriggerEvents(h.triggerEvents, h.Response.Trigger); trigger != "" {
+    header.Set("HX-Trigger", trigger)
   }
-  if h.Response.TriggerAfterSettle != "" {
-    header.Set("HX-Trigger-After-Settle", h.Response.TriggerAfterSettle)
+  if trigger := encodeTriggerEvents(h.triggerAfterSettleEvents, h.Response.TriggerAfterSettle); trigger != "" {
+    header.Set("HX-Trigger-After-Settle", trigger)
   }
-  if h.Response.TriggerAfterSwap != "" {
-    header.Set("HX-Trigger-After-Swap", h.Response.TriggerAfterSwap)
+  if trigger := encodeTriggerEvents(h.triggerAfterSwapEvents, h.Response.TriggerAfterSwap); trigger != "" {
+    header.Set("HX-Trigger-After-Swap", trigger)
   }
   return h
 }
 
+// encodeTriggerEvents renders queued structured events into the value htmx
+// expects for an HX-Trigger-style header: a comma-separated list of names
+// when every event carries a nil detail, or a JSON object of name -> detail
+// otherwise. When no events are queued, fallback (set via the raw string
+// setters such as Trigger) is returned unchanged. When events are queued
+// alongside a non-empty fallback, the fallback is folded in as another
+// plain name if every event is detail-less; mixing a raw fallback with
+// detail-carrying events has no safe merge (the fallback isn't guaranteed
+// to be valid JSON), so that combination panics rather than silently
+// dropping one side.
+func encodeTriggerEvents(events []TriggerEvent, fallback string) string {
+  if len(events) == 0 {
+    return fallback
+  }
+
+  allNil := true
+  for _, event := range events {
+    if event.Detail != nil {
+      allNil = false
+      break
+    }
+  }
+
+  if allNil {
+    names := make([]string, len(events))
+    for i, event := range events {
+      names[i] = event.Name
+    }
+    if fallback != "" {
+      names = append(names, fallback)
+    }
+    return strings.Join(names, ",")
+  }
+
+  if fallback != "" {
+    panic("htmx: cannot combine a raw Trigger string with detail-carrying TriggerEvent/TriggerEvents for the same header; use one API or the other")
+  }
+
+  payload := make(map[string]any, len(events))
+  for _, event := range events {
+    payload[event.Name] = event.Detail
+  }
+
+  encoded, err := json.Marshal(payload)
+  if err != nil {
+    panic("htmx: failed to marshal trigger events: " + err.Error())
+  }
+  return string(encoded)
+}
+
 func getHeader(header any, key string) string {
   netHttpHeader, ok := header.(RequestHeader)
   if ok {
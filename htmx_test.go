@@ -0,0 +1,139 @@
+package htmx
+
+import (
+  "strings"
+  "testing"
+  "time"
+)
+
+func TestLocationUsesPlainStringWithoutExtras(t *testing.T) {
+  h := New(stubRequestHeader{})
+  h.LocationWith(LocationSpec{Path: "/contacts/1"})
+
+  header := headerMap{}
+  h.Apply(header)
+
+  if got, want := header["HX-Location"], "/contacts/1"; got != want {
+    t.Fatalf("HX-Location = %q, want %q", got, want)
+  }
+}
+
+func TestLocationMarshalsJSONWithExtras(t *testing.T) {
+  h := New(stubRequestHeader{})
+  h.LocationWith(LocationSpec{Path: "/contacts/1", Target: "#content"})
+
+  header := headerMap{}
+  h.Apply(header)
+
+  if got, want := header["HX-Location"], `{"path":"/contacts/1","target":"#content"}`; got != want {
+    t.Fatalf("HX-Location = %q, want %q", got, want)
+  }
+}
+
+func TestRenderEscapesOOBSelector(t *testing.T) {
+  h := New(stubRequestHeader{})
+  h.AddOOB(`foo"><script>alert(1)</script`, "xx", "")
+
+  var buf strings.Builder
+  if err := h.Render(&buf, ""); err != nil {
+    t.Fatalf("Render: %v", err)
+  }
+
+  if got := buf.String(); strings.Contains(got, "<script>") {
+    t.Fatalf("Render produced unescaped markup in an attribute: %s", got)
+  }
+}
+
+type headerMap map[string]string
+
+func (h headerMap) Set(key, value string) { h[key] = value }
+
+func TestTriggerMergesWithDetaillessEvents(t *testing.T) {
+  h := New(stubRequestHeader{})
+  h.Trigger("legacy").TriggerEvent("fresh", nil)
+
+  header := headerMap{}
+  h.Apply(header)
+
+  if got, want := header["HX-Trigger"], "fresh,legacy"; got != want {
+    t.Fatalf("HX-Trigger = %q, want %q", got, want)
+  }
+}
+
+func TestTriggerPanicsWhenMixedWithDetailEvent(t *testing.T) {
+  defer func() {
+    if recover() == nil {
+      t.Fatal("expected Apply to panic when mixing Trigger with a detail-carrying TriggerEvent")
+    }
+  }()
+
+  h := New(stubRequestHeader{})
+  h.Trigger("legacy").TriggerEvent("fresh", map[string]any{"id": 1})
+  h.Apply(headerMap{})
+}
+
+type stubRequestHeader map[string]string
+
+func (h stubRequestHeader) Get(key string) string { return h[key] }
+
+func TestCurrentURLParsesHeader(t *testing.T) {
+  h := New(stubRequestHeader{"HX-Current-URL": "https://example.com/contacts?page=2"})
+
+  got, err := h.CurrentURL()
+  if err != nil {
+    t.Fatalf("CurrentURL: %v", err)
+  }
+  if got.Host != "example.com" || got.Path != "/contacts" || got.Query().Get("page") != "2" {
+    t.Fatalf("CurrentURL() = %+v, want host=example.com path=/contacts page=2", got)
+  }
+}
+
+func TestCurrentURLReturnsErrorForMalformedURL(t *testing.T) {
+  h := New(stubRequestHeader{"HX-Current-URL": "http://a b.com/"})
+
+  if _, err := h.CurrentURL(); err == nil {
+    t.Fatal("CurrentURL() error = nil, want error for malformed URL")
+  }
+}
+
+func TestHeaderReadsArbitraryRequestHeader(t *testing.T) {
+  h := New(stubRequestHeader{"HX-Whatever": "custom"})
+
+  if got := h.Header("HX-Whatever"); got != "custom" {
+    t.Fatalf("Header(%q) = %q, want %q", "HX-Whatever", got, "custom")
+  }
+}
+
+func TestIsMatchesTrigger(t *testing.T) {
+  h := New(stubRequestHeader{"HX-Trigger": "save-btn"})
+
+  if !h.Is("save-btn") {
+    t.Fatal("Is(\"save-btn\") = false, want true")
+  }
+  if h.Is("other-btn") {
+    t.Fatal("Is(\"other-btn\") = true, want false")
+  }
+}
+
+func TestHistoryRestoreMatchesIsHistoryRestoreRequest(t *testing.T) {
+  h := New(stubRequestHeader{"HX-History-Restore-Request": "true"})
+
+  if !h.HistoryRestore() {
+    t.Fatal("HistoryRestore() = false, want true")
+  }
+  if h.HistoryRestore() != h.IsHistoryRestoreRequest() {
+    t.Fatal("HistoryRestore() and IsHistoryRestoreRequest() disagree")
+  }
+}
+
+func TestSwapSpecStringUsesMillisecondSuffix(t *testing.T) {
+  got := NewSwap(SwapOuterHTML).SwapDelay(500 * time.Microsecond).String()
+  if want := "outerHTML swap:0ms"; got != want {
+    t.Fatalf("String() = %q, want %q", got, want)
+  }
+
+  got = NewSwap(SwapOuterHTML).SwapDelay(1500 * time.Microsecond).SettleDelay(2 * time.Second).String()
+  if want := "outerHTML swap:1ms settle:2000ms"; got != want {
+    t.Fatalf("String() = %q, want %q", got, want)
+  }
+}
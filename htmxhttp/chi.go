@@ -0,0 +1,9 @@
+package htmxhttp
+
+import "net/http"
+
+// ChiMiddleware adapts Middleware for chi, whose router accepts ordinary
+// func(http.Handler) http.Handler middleware (e.g. r.Use(htmxhttp.ChiMiddleware)).
+func ChiMiddleware(next http.Handler) http.Handler {
+  return Middleware(next)
+}
@@ -0,0 +1,67 @@
+package htmxhttp
+
+import (
+  "net/http"
+
+  "github.com/dajooo/go-htmx"
+)
+
+// EchoResponse is the subset of *echo.Response this adapter needs. Before
+// registers a callback that echo invokes right before it commits the
+// status line and headers (echo.Response.Before), which is required here:
+// c.Response().Header() is the same map the underlying http.ResponseWriter
+// uses, and per http.ResponseWriter's contract, header mutations made after
+// the first WriteHeader/Write are silently ignored.
+type EchoResponse interface {
+  Header() http.Header
+  Before(fn func())
+}
+
+// EchoContext is the subset of echo.Context this adapter needs. A real
+// echo.Context satisfies this as-is, so it can be passed directly; no
+// import of the echo module is required here.
+type EchoContext interface {
+  Request() *http.Request
+  Response() EchoResponse
+  Set(key string, val any)
+  Get(key string) any
+}
+
+const echoContextKey = "htmx"
+
+// EchoHandlerFunc matches echo's handler signature, kept local so this
+// package doesn't import the echo module.
+type EchoHandlerFunc func(c EchoContext) error
+
+// EchoMiddleware builds an *htmx.Htmx from c.Request(), stores it under
+// c.Set so downstream handlers can fetch it with FromEcho, and applies the
+// response. It registers the apply via c.Response().Before so it runs
+// before echo commits headers on the handler's first write, then applies
+// once more (a no-op by then) if next returns without writing at all.
+func EchoMiddleware(next EchoHandlerFunc) EchoHandlerFunc {
+  return func(c EchoContext) error {
+    h := htmx.New(c.Request().Header)
+    c.Set(echoContextKey, h)
+
+    applied := false
+    apply := func() {
+      if applied {
+        return
+      }
+      applied = true
+      h.Apply(c.Response().Header())
+    }
+    c.Response().Before(apply)
+
+    err := next(c)
+    apply()
+    return err
+  }
+}
+
+// FromEcho returns the *htmx.Htmx stored on c by EchoMiddleware, or nil if
+// none was stored.
+func FromEcho(c EchoContext) *htmx.Htmx {
+  h, _ := c.Get(echoContextKey).(*htmx.Htmx)
+  return h
+}
@@ -0,0 +1,112 @@
+package htmxhttp
+
+import (
+  "net/http"
+  "net/http/httptest"
+  "testing"
+)
+
+// fakeEchoResponse mirrors the parts of echo v4's *echo.Response relevant
+// here: Header() returns the underlying http.ResponseWriter's own header
+// map (so mutating it after the first Write/WriteHeader has already hit
+// the wire is a no-op), and Before registers callbacks run exactly once,
+// right before that first commit.
+type fakeEchoResponse struct {
+  http.ResponseWriter
+  befores   []func()
+  committed bool
+}
+
+func (r *fakeEchoResponse) Before(fn func()) {
+  r.befores = append(r.befores, fn)
+}
+
+func (r *fakeEchoResponse) WriteHeader(code int) {
+  if r.committed {
+    return
+  }
+  r.committed = true
+  for _, fn := range r.befores {
+    fn()
+  }
+  r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *fakeEchoResponse) Write(b []byte) (int, error) {
+  if !r.committed {
+    r.WriteHeader(http.StatusOK)
+  }
+  return r.ResponseWriter.Write(b)
+}
+
+type fakeEchoContext struct {
+  req    *http.Request
+  res    *fakeEchoResponse
+  values map[string]any
+}
+
+func (c *fakeEchoContext) Request() *http.Request  { return c.req }
+func (c *fakeEchoContext) Response() EchoResponse   { return c.res }
+func (c *fakeEchoContext) Set(key string, val any)  { c.values[key] = val }
+func (c *fakeEchoContext) Get(key string) any       { return c.values[key] }
+
+func TestEchoMiddlewareAppliesHeadersBeforeHandlerWrites(t *testing.T) {
+  srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    c := &fakeEchoContext{
+      req:    r,
+      res:    &fakeEchoResponse{ResponseWriter: w},
+      values: map[string]any{},
+    }
+
+    handler := EchoMiddleware(func(c EchoContext) error {
+      FromEcho(c).Trigger("saved")
+      _, err := c.Response().(http.ResponseWriter).Write([]byte("ok"))
+      return err
+    })
+
+    if err := handler(c); err != nil {
+      t.Errorf("handler: %v", err)
+    }
+  }))
+  defer srv.Close()
+
+  resp, err := http.Get(srv.URL)
+  if err != nil {
+    t.Fatalf("GET: %v", err)
+  }
+  defer resp.Body.Close()
+
+  if got := resp.Header.Get("Hx-Trigger"); got != "saved" {
+    t.Fatalf("HX-Trigger = %q, want %q (header mutated after the response committed is silently dropped)", got, "saved")
+  }
+}
+
+func TestEchoMiddlewareAppliesHeadersWithoutExplicitWrite(t *testing.T) {
+  srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    c := &fakeEchoContext{
+      req:    r,
+      res:    &fakeEchoResponse{ResponseWriter: w},
+      values: map[string]any{},
+    }
+
+    handler := EchoMiddleware(func(c EchoContext) error {
+      FromEcho(c).Trigger("saved")
+      return nil
+    })
+
+    if err := handler(c); err != nil {
+      t.Errorf("handler: %v", err)
+    }
+  }))
+  defer srv.Close()
+
+  resp, err := http.Get(srv.URL)
+  if err != nil {
+    t.Fatalf("GET: %v", err)
+  }
+  defer resp.Body.Close()
+
+  if got := resp.Header.Get("Hx-Trigger"); got != "saved" {
+    t.Fatalf("HX-Trigger = %q, want %q", got, "saved")
+  }
+}
@@ -0,0 +1,20 @@
+package htmxhttp
+
+import "github.com/dajooo/go-htmx"
+
+// FastHTTPHandlerFunc mirrors a fasthttp handler split into its request and
+// response headers, e.g. called as
+// FastHTTPMiddleware(next)(&ctx.Request.Header, &ctx.Response.Header).
+type FastHTTPHandlerFunc func(h *htmx.Htmx, requestHeader htmx.FastHttpRequestHeader, responseHeader htmx.ResponseHeader)
+
+// FastHTTPMiddleware builds an *htmx.Htmx from requestHeader, runs next, and
+// applies the accumulated response to responseHeader. It only depends on
+// the Get/Set header methods already modeled by htmx.FastHttpRequestHeader
+// and htmx.ResponseHeader, so it has no hard dependency on fasthttp itself.
+func FastHTTPMiddleware(next FastHTTPHandlerFunc) func(requestHeader htmx.FastHttpRequestHeader, responseHeader htmx.ResponseHeader) {
+  return func(requestHeader htmx.FastHttpRequestHeader, responseHeader htmx.ResponseHeader) {
+    h := htmx.NewFastHttp(requestHeader)
+    next(h, requestHeader, responseHeader)
+    h.Apply(responseHeader)
+  }
+}
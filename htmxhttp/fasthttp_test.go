@@ -0,0 +1,40 @@
+package htmxhttp
+
+import (
+  "testing"
+
+  "github.com/dajooo/go-htmx"
+)
+
+type fakeFastHTTPRequestHeader map[string]string
+
+func (h fakeFastHTTPRequestHeader) Get(key string, defaultValue ...string) string {
+  if v, ok := h[key]; ok {
+    return v
+  }
+  if len(defaultValue) > 0 {
+    return defaultValue[0]
+  }
+  return ""
+}
+
+type fakeFastHTTPResponseHeader map[string]string
+
+func (h fakeFastHTTPResponseHeader) Set(key, value string) {
+  h[key] = value
+}
+
+func TestFastHTTPMiddlewareAppliesHeadersAfterHandlerReturns(t *testing.T) {
+  requestHeader := fakeFastHTTPRequestHeader{}
+  responseHeader := fakeFastHTTPResponseHeader{}
+
+  handler := FastHTTPMiddleware(func(h *htmx.Htmx, requestHeader htmx.FastHttpRequestHeader, responseHeader htmx.ResponseHeader) {
+    h.Trigger("saved")
+  })
+
+  handler(requestHeader, responseHeader)
+
+  if got := responseHeader["HX-Trigger"]; got != "saved" {
+    t.Fatalf("HX-Trigger = %q, want %q", got, "saved")
+  }
+}
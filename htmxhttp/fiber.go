@@ -0,0 +1,38 @@
+package htmxhttp
+
+import "github.com/dajooo/go-htmx"
+
+// FiberCtx is the subset of *fiber.Ctx this adapter needs: header access
+// (shared with the fasthttp request/response contract) plus per-request
+// value storage. A real *fiber.Ctx satisfies this as-is.
+type FiberCtx interface {
+  htmx.FastHttpRequestHeader
+  htmx.ResponseHeader
+  Locals(key any, value ...any) any
+}
+
+const fiberLocalsKey = "htmx"
+
+// FiberHandlerFunc matches fiber's handler signature, kept local so this
+// package doesn't import the fiber module.
+type FiberHandlerFunc func(c FiberCtx) error
+
+// FiberMiddleware builds an *htmx.Htmx from c, stores it under c.Locals so
+// downstream handlers can fetch it with FromFiber, and applies the response
+// after next returns.
+func FiberMiddleware(next FiberHandlerFunc) FiberHandlerFunc {
+  return func(c FiberCtx) error {
+    h := htmx.NewFastHttp(c)
+    c.Locals(fiberLocalsKey, h)
+    err := next(c)
+    h.Apply(c)
+    return err
+  }
+}
+
+// FromFiber returns the *htmx.Htmx stored on c by FiberMiddleware, or nil if
+// none was stored.
+func FromFiber(c FiberCtx) *htmx.Htmx {
+  h, _ := c.Locals(fiberLocalsKey).(*htmx.Htmx)
+  return h
+}
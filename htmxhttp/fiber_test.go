@@ -0,0 +1,66 @@
+package htmxhttp
+
+import "testing"
+
+// fakeFiberCtx is a minimal stand-in for *fiber.Ctx: fasthttp (which fiber
+// wraps) buffers the whole response and only serializes headers once the
+// handler returns, so unlike net/http or echo there is no incremental
+// commit to race against an apply-after-return.
+type fakeFiberCtx struct {
+  requestHeaders  map[string]string
+  responseHeaders map[string]string
+  locals          map[any]any
+}
+
+func newFakeFiberCtx() *fakeFiberCtx {
+  return &fakeFiberCtx{
+    requestHeaders:  map[string]string{},
+    responseHeaders: map[string]string{},
+    locals:          map[any]any{},
+  }
+}
+
+func (c *fakeFiberCtx) Get(key string, defaultValue ...string) string {
+  if v, ok := c.requestHeaders[key]; ok {
+    return v
+  }
+  if len(defaultValue) > 0 {
+    return defaultValue[0]
+  }
+  return ""
+}
+
+func (c *fakeFiberCtx) Set(key, value string) {
+  c.responseHeaders[key] = value
+}
+
+func (c *fakeFiberCtx) Locals(key any, value ...any) any {
+  if len(value) > 0 {
+    c.locals[key] = value[0]
+    return nil
+  }
+  return c.locals[key]
+}
+
+func TestFiberMiddlewareAppliesHeadersAfterHandlerReturns(t *testing.T) {
+  c := newFakeFiberCtx()
+
+  handler := FiberMiddleware(func(c FiberCtx) error {
+    FromFiber(c).Trigger("saved")
+    return nil
+  })
+
+  if err := handler(c); err != nil {
+    t.Fatalf("handler: %v", err)
+  }
+
+  if got := c.responseHeaders["HX-Trigger"]; got != "saved" {
+    t.Fatalf("HX-Trigger = %q, want %q", got, "saved")
+  }
+}
+
+func TestFromFiberReturnsNilWithoutMiddleware(t *testing.T) {
+  if got := FromFiber(newFakeFiberCtx()); got != nil {
+    t.Fatalf("FromFiber() = %v, want nil", got)
+  }
+}
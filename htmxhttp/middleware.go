@@ -0,0 +1,68 @@
+// Package htmxhttp wires *htmx.Htmx into common Go HTTP stacks so handlers
+// don't have to manually call htmx.New(r.Header) and Apply(w.Header()) in
+// every handler. It depends only on net/http; adapters for other routers and
+// frameworks (fasthttp, echo, fiber, chi) are expressed as small structural
+// interfaces so this package never needs those frameworks as a dependency.
+package htmxhttp
+
+import (
+  "context"
+  "net/http"
+
+  "github.com/dajooo/go-htmx"
+)
+
+type contextKey struct{}
+
+var htmxContextKey = contextKey{}
+
+// Middleware constructs an *htmx.Htmx from the incoming request, stores it
+// on the request context, and applies its response headers to w. The
+// wrapping ResponseWriter applies them as soon as the handler starts
+// writing, and Middleware applies them again (a no-op by then) once next
+// returns, so handlers that set response state without ever calling
+// Write/WriteHeader (e.g. a bare "200 OK" htmx trigger) still get their
+// headers flushed. Downstream handlers retrieve the Htmx with FromContext.
+func Middleware(next http.Handler) http.Handler {
+  return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    h := htmx.New(r.Header)
+    ctx := context.WithValue(r.Context(), htmxContextKey, h)
+    rw := &responseWriter{ResponseWriter: w, htmx: h}
+    next.ServeHTTP(rw, r.WithContext(ctx))
+    rw.apply()
+  })
+}
+
+// FromContext returns the *htmx.Htmx stored on ctx by Middleware, or nil if
+// none was stored.
+func FromContext(ctx context.Context) *htmx.Htmx {
+  h, _ := ctx.Value(htmxContextKey).(*htmx.Htmx)
+  return h
+}
+
+// responseWriter applies h's queued response headers the first time the
+// handler writes a status code or body, so callers never have to remember
+// to call Apply themselves.
+type responseWriter struct {
+  http.ResponseWriter
+  htmx    *htmx.Htmx
+  applied bool
+}
+
+func (w *responseWriter) apply() {
+  if w.applied {
+    return
+  }
+  w.applied = true
+  w.htmx.Apply(w.ResponseWriter.Header())
+}
+
+func (w *responseWriter) WriteHeader(statusCode int) {
+  w.apply()
+  w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+  w.apply()
+  return w.ResponseWriter.Write(b)
+}
@@ -0,0 +1,40 @@
+package htmxhttp
+
+import (
+  "net/http"
+  "net/http/httptest"
+  "testing"
+)
+
+func TestMiddlewareAppliesHeadersWithoutExplicitWrite(t *testing.T) {
+  handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    FromContext(r.Context()).Trigger("saved")
+  }))
+
+  rec := httptest.NewRecorder()
+  handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+  if got := rec.Header().Get("HX-Trigger"); got != "saved" {
+    t.Fatalf("HX-Trigger = %q, want %q", got, "saved")
+  }
+}
+
+func TestChiMiddlewareAppliesHeadersBeforeWrite(t *testing.T) {
+  handler := ChiMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    FromContext(r.Context()).Trigger("saved")
+    w.Write([]byte("ok"))
+  }))
+
+  srv := httptest.NewServer(handler)
+  defer srv.Close()
+
+  resp, err := http.Get(srv.URL)
+  if err != nil {
+    t.Fatalf("GET: %v", err)
+  }
+  defer resp.Body.Close()
+
+  if got := resp.Header.Get("Hx-Trigger"); got != "saved" {
+    t.Fatalf("HX-Trigger = %q, want %q", got, "saved")
+  }
+}